@@ -0,0 +1,125 @@
+package v1alpha1
+
+import (
+	argov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ApplicationSet is a set of Application resources
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+// +kubebuilder:resource:path=applicationsets,shortName=appset;appsets
+type ApplicationSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+
+	Spec   ApplicationSetSpec   `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+	Status ApplicationSetStatus `json:"status,omitempty" protobuf:"bytes,3,opt,name=status"`
+}
+
+// ApplicationSetSpec represents a class of application set state.
+type ApplicationSetSpec struct {
+	GoTemplate bool                      `json:"goTemplate,omitempty" protobuf:"bytes,1,opt,name=goTemplate"`
+	Generators []ApplicationSetGenerator `json:"generators" protobuf:"bytes,2,opt,name=generators"`
+	Template   ApplicationSetTemplate    `json:"template" protobuf:"bytes,3,opt,name=template"`
+	SyncPolicy *ApplicationSetSyncPolicy `json:"syncPolicy,omitempty" protobuf:"bytes,4,opt,name=syncPolicy"`
+	Strategy   *ApplicationSetStrategy   `json:"strategy,omitempty" protobuf:"bytes,5,opt,name=strategy"`
+	// TemplatePatch is an optional template used to patch the generated Application resource after
+	// the main template has been rendered. It is applied as a strategic merge patch, which for fields
+	// without a patch strategy behaves like a JSON merge patch.
+	TemplatePatch *string `json:"templatePatch,omitempty" protobuf:"bytes,6,opt,name=templatePatch"`
+	// GoTemplateOptions is passed verbatim to Go text/template's Option when GoTemplate is true, e.g.
+	// []string{"missingkey=error"}.
+	GoTemplateOptions []string `json:"goTemplateOptions,omitempty" protobuf:"bytes,7,opt,name=goTemplateOptions"`
+}
+
+// ApplicationSetTemplate represents argocd ApplicationSpec
+type ApplicationSetTemplate struct {
+	metav1.ObjectMeta `json:"metadata" protobuf:"bytes,1,opt,name=metadata"`
+	Spec              argov1alpha1.ApplicationSpec `json:"spec" protobuf:"bytes,2,opt,name=spec"`
+}
+
+// ApplicationSetSyncPolicy configures how generated Applications are deleted/finalized.
+type ApplicationSetSyncPolicy struct {
+	// PreserveResourcesOnDeletion will preserve resources on deletion of the ApplicationSet generated Applications
+	PreserveResourcesOnDeletion bool `json:"preserveResourcesOnDeletion,omitempty" protobuf:"bytes,1,opt,name=preserveResourcesOnDeletion"`
+}
+
+// ApplicationSetStrategy configures how generated Applications are updated in sequence.
+type ApplicationSetStrategy struct {
+	Type string `json:"type,omitempty" protobuf:"bytes,1,opt,name=type"`
+}
+
+// ApplicationSetGenerator includes a specific generator, or a combination of generators.
+type ApplicationSetGenerator struct {
+	List     *ListGenerator    `json:"list,omitempty" protobuf:"bytes,1,opt,name=list"`
+	Clusters *ClusterGenerator `json:"clusters,omitempty" protobuf:"bytes,2,opt,name=clusters"`
+	Git      *GitGenerator     `json:"git,omitempty" protobuf:"bytes,3,opt,name=git"`
+	Plugin   *PluginGenerator  `json:"plugin,omitempty" protobuf:"bytes,5,opt,name=plugin"`
+
+	Selector *metav1.LabelSelector `json:"selector,omitempty" protobuf:"bytes,4,opt,name=selector"`
+}
+
+// ListGenerator includes items info
+type ListGenerator struct {
+	Elements []map[string]string    `json:"elements" protobuf:"bytes,1,opt,name=elements"`
+	Template ApplicationSetTemplate `json:"template,omitempty" protobuf:"bytes,2,opt,name=template"`
+}
+
+// ClusterGenerator defines a generator to match against clusters registered with ArgoCD.
+type ClusterGenerator struct {
+	Selector metav1.LabelSelector   `json:"selector,omitempty" protobuf:"bytes,1,opt,name=selector"`
+	Template ApplicationSetTemplate `json:"template,omitempty" protobuf:"bytes,2,opt,name=template"`
+}
+
+// GitGenerator defines a generator that retrieves directories/files from a Git repository.
+type GitGenerator struct {
+	RepoURL     string                      `json:"repoURL" protobuf:"bytes,1,opt,name=repoURL"`
+	Directories []GitDirectoryGeneratorItem `json:"directories,omitempty" protobuf:"bytes,2,opt,name=directories"`
+	Files       []GitFileGeneratorItem      `json:"files,omitempty" protobuf:"bytes,3,opt,name=files"`
+	Revision    string                      `json:"revision" protobuf:"bytes,4,opt,name=revision"`
+	Template    ApplicationSetTemplate      `json:"template,omitempty" protobuf:"bytes,5,opt,name=template"`
+}
+
+// GitDirectoryGeneratorItem describes a directory to match against the Git repository.
+type GitDirectoryGeneratorItem struct {
+	Path    string `json:"path" protobuf:"bytes,1,opt,name=path"`
+	Exclude bool   `json:"exclude,omitempty" protobuf:"bytes,2,opt,name=exclude"`
+}
+
+// GitFileGeneratorItem describes a file to match against the Git repository.
+type GitFileGeneratorItem struct {
+	Path string `json:"path" protobuf:"bytes,1,opt,name=path"`
+}
+
+// ApplicationSetConditionType represents the type of the condition
+type ApplicationSetConditionType string
+
+const (
+	ApplicationSetConditionErrorOccurred       ApplicationSetConditionType = "ErrorOccurred"
+	ApplicationSetConditionParametersGenerated ApplicationSetConditionType = "ParametersGenerated"
+	ApplicationSetConditionResourcesUpToDate   ApplicationSetConditionType = "ResourcesUpToDate"
+)
+
+// ApplicationSetCondition contains details about an applicationset condition
+type ApplicationSetCondition struct {
+	Type               ApplicationSetConditionType `json:"type" protobuf:"bytes,1,opt,name=type"`
+	Message            string                      `json:"message" protobuf:"bytes,2,opt,name=message"`
+	Status             string                      `json:"status" protobuf:"bytes,3,opt,name=status"`
+	LastTransitionTime *metav1.Time                `json:"lastTransitionTime,omitempty" protobuf:"bytes,4,opt,name=lastTransitionTime"`
+	Reason             string                      `json:"reason,omitempty" protobuf:"bytes,5,opt,name=reason"`
+	GeneratorName      string                      `json:"generatorName,omitempty" protobuf:"bytes,6,opt,name=generatorName"`
+}
+
+// ApplicationSetStatus defines the observed state of ApplicationSet
+type ApplicationSetStatus struct {
+	Conditions []ApplicationSetCondition `json:"conditions,omitempty" protobuf:"bytes,1,opt,name=conditions"`
+}
+
+// ApplicationSetList contains a list of ApplicationSet
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ApplicationSetList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty" protobuf:"bytes,1,opt,name=metadata"`
+	Items           []ApplicationSet `json:"items" protobuf:"bytes,2,rep,name=items"`
+}
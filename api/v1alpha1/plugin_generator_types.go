@@ -0,0 +1,40 @@
+package v1alpha1
+
+// PluginGenerator calls out to an external HTTP service to generate the list of template parameters.
+// The endpoint URL and bearer token are resolved from a Secret named by ConfigRef, so credentials
+// never need to live directly on the ApplicationSet.
+type PluginGenerator struct {
+	// ConfigRef points at the Secret holding the plugin's endpoint URL and, optionally, its bearer
+	// token.
+	ConfigRef PluginConfigMapRef `json:"configRef" protobuf:"bytes,1,opt,name=configRef"`
+
+	// Name identifies which plugin to invoke. It is included in the request body so a single
+	// endpoint can serve more than one plugin.
+	Name string `json:"name" protobuf:"bytes,2,opt,name=name"`
+
+	// Input is passed through verbatim as input.parameters in the request body.
+	Input PluginInput `json:"input,omitempty" protobuf:"bytes,3,opt,name=input"`
+
+	// Values are additional parameters merged into every params map the plugin returns, taking
+	// precedence over anything the plugin itself returned under the same key.
+	Values map[string]string `json:"values,omitempty" protobuf:"bytes,4,opt,name=values"`
+
+	// RequestTimeout bounds how long to wait for the plugin to respond, in seconds. Defaults to 30.
+	RequestTimeout int64 `json:"requestTimeout,omitempty" protobuf:"bytes,5,opt,name=requestTimeout"`
+}
+
+// PluginConfigMapRef identifies the Secret backing a PluginGenerator, despite its name - the Secret
+// holds credentials (the bearer token), which a ConfigMap cannot store safely, so only a Secret is
+// ever resolved. The name is kept for compatibility with the upstream field it mirrors.
+type PluginConfigMapRef struct {
+	Name string `json:"name" protobuf:"bytes,1,opt,name=name"`
+
+	// InsecureSkipVerify disables TLS certificate verification when calling the plugin endpoint.
+	// Defaults to false; only set this for endpoints known to use self-signed certificates.
+	InsecureSkipVerify bool `json:"insecureSkipVerify,omitempty" protobuf:"bytes,2,opt,name=insecureSkipVerify"`
+}
+
+// PluginInput is the user-supplied portion of a PluginGenerator's request body.
+type PluginInput struct {
+	Parameters map[string]string `json:"parameters,omitempty" protobuf:"bytes,1,opt,name=parameters"`
+}
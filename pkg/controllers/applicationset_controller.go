@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	"github.com/argoproj-labs/applicationset/pkg/generators"
+	"github.com/argoproj-labs/applicationset/pkg/utils"
+	argov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// generatorErrorRequeueInterval is how long Reconcile waits before retrying an ApplicationSet whose
+// generators didn't all succeed (e.g. a plugin timeout, an unreachable git repo).
+const generatorErrorRequeueInterval = 3 * time.Minute
+
+// ApplicationSetReconciler reconciles a ApplicationSet object
+type ApplicationSetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+
+	// K8sClient is used by generators (currently, the Plugin generator) that need direct client-go
+	// access, e.g. to read the Secret backing a plugin's endpoint config.
+	K8sClient kubernetes.Interface
+}
+
+// Reconcile generates the Applications for a single ApplicationSet and creates/updates them,
+// reporting any generator failures as status conditions rather than failing the whole reconcile. It
+// is re-run whenever the ApplicationSet changes and, if any generator failed, again after
+// generatorErrorRequeueInterval.
+func (r *ApplicationSetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var applicationSetInfo argoprojiov1alpha1.ApplicationSet
+	if err := r.Get(ctx, req.NamespacedName, &applicationSetInfo); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, fmt.Errorf("failed to get ApplicationSet %q: %w", req.NamespacedName, err)
+	}
+
+	utils.CheckInvalidGenerators(&applicationSetInfo)
+
+	run := func(index int, generator argoprojiov1alpha1.ApplicationSetGenerator) ([]map[string]interface{}, error) {
+		return r.generateParams(applicationSetInfo.Namespace, generator)
+	}
+
+	apps, conditions, requeue, err := r.syncApplicationSet(applicationSetInfo, run)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to generate applications for ApplicationSet %q: %w", applicationSetInfo.Name, err)
+	}
+
+	if err := r.createOrUpdateApplications(ctx, &applicationSetInfo, apps); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to create/update applications for ApplicationSet %q: %w", applicationSetInfo.Name, err)
+	}
+
+	applicationSetInfo.Status.Conditions = conditions
+	if err := r.Status().Update(ctx, &applicationSetInfo); err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to update status for ApplicationSet %q: %w", applicationSetInfo.Name, err)
+	}
+
+	if requeue {
+		return ctrl.Result{RequeueAfter: generatorErrorRequeueInterval}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager registers the reconciler with mgr, watching ApplicationSets and the Applications
+// they own.
+func (r *ApplicationSetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&argoprojiov1alpha1.ApplicationSet{}).
+		Owns(&argov1alpha1.Application{}).
+		Complete(r)
+}
+
+// generateParams runs the Generator configured by generator and filters its output through
+// generators.Transform, so that generator.Selector is applied before the params reach the template.
+func (r *ApplicationSetReconciler) generateParams(namespace string, generator argoprojiov1alpha1.ApplicationSetGenerator) ([]map[string]interface{}, error) {
+	gen, err := r.generatorFor(namespace, generator)
+	if err != nil {
+		return nil, err
+	}
+
+	paramsList, err := gen.GenerateParams(&generator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate params: %w", err)
+	}
+
+	return generators.Transform(generator, paramsList)
+}
+
+// generatorFor returns the Generator implementation for generator's configured type.
+func (r *ApplicationSetReconciler) generatorFor(namespace string, generator argoprojiov1alpha1.ApplicationSetGenerator) (generators.Generator, error) {
+	switch {
+	case generator.Plugin != nil:
+		return generators.NewPluginGenerator(r.K8sClient, namespace), nil
+	case generator.List != nil, generator.Clusters != nil, generator.Git != nil:
+		return nil, fmt.Errorf("this generator type is not yet implemented")
+	default:
+		return nil, fmt.Errorf("generator entry does not configure any known generator type")
+	}
+}
+
+// createOrUpdateApplications creates any Application in apps that doesn't yet exist, and updates the
+// spec of any that does, owned by applicationSetInfo so they're garbage-collected with it.
+func (r *ApplicationSetReconciler) createOrUpdateApplications(ctx context.Context, applicationSetInfo *argoprojiov1alpha1.ApplicationSet, apps []argov1alpha1.Application) error {
+	for i := range apps {
+		app := &apps[i]
+		app.Namespace = applicationSetInfo.Namespace
+
+		if err := controllerutil.SetControllerReference(applicationSetInfo, app, r.Scheme); err != nil {
+			return fmt.Errorf("failed to set owner reference on application %q: %w", app.Name, err)
+		}
+
+		var existing argov1alpha1.Application
+		err := r.Get(ctx, types.NamespacedName{Namespace: app.Namespace, Name: app.Name}, &existing)
+		switch {
+		case apierrors.IsNotFound(err):
+			if err := r.Create(ctx, app); err != nil {
+				return fmt.Errorf("failed to create application %q: %w", app.Name, err)
+			}
+		case err != nil:
+			return fmt.Errorf("failed to get application %q: %w", app.Name, err)
+		default:
+			existing.Spec = app.Spec
+			existing.Finalizers = app.Finalizers
+			if err := r.Update(ctx, &existing); err != nil {
+				return fmt.Errorf("failed to update application %q: %w", app.Name, err)
+			}
+		}
+	}
+	return nil
+}
+
+// reconcileGenerators runs every generator of applicationSetInfo independently, so that one broken
+// generator (an empty entry, a plugin timeout, an unreachable git repo) doesn't prevent the
+// Applications produced by the other, healthy generators from being created/updated. It returns the
+// combined params from the generators that succeeded, plus one GeneratorError per generator that
+// failed.
+func (r *ApplicationSetReconciler) reconcileGenerators(applicationSetInfo argoprojiov1alpha1.ApplicationSet, run func(index int, generator argoprojiov1alpha1.ApplicationSetGenerator) ([]map[string]interface{}, error)) ([]map[string]interface{}, []utils.GeneratorError) {
+	results := make([]utils.GeneratorResult, len(applicationSetInfo.Spec.Generators))
+	for index, generator := range applicationSetInfo.Spec.Generators {
+		params, err := run(index, generator)
+		results[index] = utils.GeneratorResult{Index: index, Params: params, Err: err}
+	}
+	return utils.PartitionGeneratorResults(results)
+}
+
+// conditionsForGeneratorErrors turns the given GeneratorErrors into ApplicationSetConditions, one
+// ErrorOccurred condition per failed generator, identifying the offending generator by index.
+func conditionsForGeneratorErrors(errs []utils.GeneratorError) []argoprojiov1alpha1.ApplicationSetCondition {
+	conditions := make([]argoprojiov1alpha1.ApplicationSetCondition, 0, len(errs))
+	for _, genErr := range errs {
+		conditions = append(conditions, argoprojiov1alpha1.ApplicationSetCondition{
+			Type:          argoprojiov1alpha1.ApplicationSetConditionErrorOccurred,
+			Status:        "True",
+			Reason:        "ErrorOccurred",
+			Message:       genErr.Message,
+			GeneratorName: strings.TrimSpace(fmt.Sprintf("generators[%d]", genErr.Index)),
+		})
+	}
+	return conditions
+}
+
+// generateApplications renders one Application per params entry from the ApplicationSet template,
+// running the base template substitution first and, if spec.templatePatch is set, patching the
+// result with the rendered templatePatch afterwards.
+func (r *ApplicationSetReconciler) generateApplications(applicationSetInfo argoprojiov1alpha1.ApplicationSet, allParams []map[string]interface{}) ([]argov1alpha1.Application, error) {
+	render := utils.Render{
+		GoTemplate:        applicationSetInfo.Spec.GoTemplate,
+		GoTemplateOptions: applicationSetInfo.Spec.GoTemplateOptions,
+	}
+
+	var apps []argov1alpha1.Application
+	for _, params := range allParams {
+		tmplApplication := getTemplateApplication(applicationSetInfo)
+
+		app, err := render.RenderTemplateParams(tmplApplication, applicationSetInfo.Spec.SyncPolicy, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to render template params for application set %q: %w", applicationSetInfo.Name, err)
+		}
+
+		if applicationSetInfo.Spec.TemplatePatch != nil {
+			app, err = render.ApplyTemplatePatch(app, *applicationSetInfo.Spec.TemplatePatch, params)
+			if err != nil {
+				return nil, fmt.Errorf("failed to apply templatePatch for application set %q: %w", applicationSetInfo.Name, err)
+			}
+		}
+
+		apps = append(apps, *app)
+	}
+	return apps, nil
+}
+
+// syncApplicationSet runs every generator of applicationSetInfo, renders an Application for each
+// params entry produced by the generators that succeeded, and reports the generators that failed as
+// conditions. It never fails outright because of a broken generator: the healthy generators' apps are
+// always returned, and requeue is true whenever at least one generator needs to be retried.
+func (r *ApplicationSetReconciler) syncApplicationSet(applicationSetInfo argoprojiov1alpha1.ApplicationSet, run func(index int, generator argoprojiov1alpha1.ApplicationSetGenerator) ([]map[string]interface{}, error)) ([]argov1alpha1.Application, []argoprojiov1alpha1.ApplicationSetCondition, bool, error) {
+	allParams, generatorErrs := r.reconcileGenerators(applicationSetInfo, run)
+
+	apps, err := r.generateApplications(applicationSetInfo, allParams)
+	if err != nil {
+		return nil, nil, false, err
+	}
+
+	return apps, conditionsForGeneratorErrors(generatorErrs), len(generatorErrs) > 0, nil
+}
+
+// getTemplateApplication converts the ApplicationSet's embedded template into a standalone
+// Application, ready to be rendered with a given generator's params.
+func getTemplateApplication(applicationSetInfo argoprojiov1alpha1.ApplicationSet) *argov1alpha1.Application {
+	return &argov1alpha1.Application{
+		ObjectMeta: applicationSetInfo.Spec.Template.ObjectMeta,
+		Spec:       applicationSetInfo.Spec.Template.Spec,
+	}
+}
@@ -0,0 +1,139 @@
+package controllers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	argov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	k8sfake "k8s.io/client-go/kubernetes/fake"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newTestScheme(t *testing.T) *runtime.Scheme {
+	scheme := runtime.NewScheme()
+	assert.NoError(t, argoprojiov1alpha1.AddToScheme(scheme))
+	assert.NoError(t, argov1alpha1.AddToScheme(scheme))
+	assert.NoError(t, corev1.AddToScheme(scheme))
+	return scheme
+}
+
+func TestReconcile(t *testing.T) {
+	t.Run("creates an Application from a plugin generator's params", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"output":{"parameters":[{"name":"guestbook"}]}}`))
+		}))
+		defer server.Close()
+
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "plugin-config", Namespace: "argocd"},
+			Data:       map[string][]byte{"baseUrl": []byte(server.URL)},
+		}
+
+		appSet := &argoprojiov1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-appset", Namespace: "argocd"},
+			Spec: argoprojiov1alpha1.ApplicationSetSpec{
+				Generators: []argoprojiov1alpha1.ApplicationSetGenerator{
+					{Plugin: &argoprojiov1alpha1.PluginGenerator{
+						Name:      "my-plugin",
+						ConfigRef: argoprojiov1alpha1.PluginConfigMapRef{Name: "plugin-config"},
+					}},
+				},
+				Template: argoprojiov1alpha1.ApplicationSetTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "{{name}}"},
+					Spec: argov1alpha1.ApplicationSpec{
+						Source:      argov1alpha1.ApplicationSource{RepoURL: "https://git.example.com/repo.git", Path: "guestbook"},
+						Destination: argov1alpha1.ApplicationDestination{Server: "https://kubernetes.default.svc"},
+						Project:     "default",
+					},
+				},
+			},
+		}
+
+		scheme := newTestScheme(t)
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(appSet).
+			WithStatusSubresource(&argoprojiov1alpha1.ApplicationSet{}).
+			Build()
+
+		r := &ApplicationSetReconciler{
+			Client:    k8sClient,
+			Scheme:    scheme,
+			K8sClient: k8sfake.NewSimpleClientset(secret),
+		}
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-appset", Namespace: "argocd"}})
+		assert.NoError(t, err)
+		assert.Zero(t, result.RequeueAfter)
+
+		var app argov1alpha1.Application
+		assert.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: "guestbook", Namespace: "argocd"}, &app))
+		assert.Equal(t, "https://git.example.com/repo.git", app.Spec.Source.RepoURL)
+		assert.Equal(t, "my-appset", app.OwnerReferences[0].Name)
+
+		var updated argoprojiov1alpha1.ApplicationSet
+		assert.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: "my-appset", Namespace: "argocd"}, &updated))
+		assert.Empty(t, updated.Status.Conditions)
+	})
+
+	t.Run("reports an unimplemented generator type as a status condition and requeues", func(t *testing.T) {
+		appSet := &argoprojiov1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "my-appset", Namespace: "argocd"},
+			Spec: argoprojiov1alpha1.ApplicationSetSpec{
+				Generators: []argoprojiov1alpha1.ApplicationSetGenerator{
+					{List: &argoprojiov1alpha1.ListGenerator{}},
+				},
+				Template: argoprojiov1alpha1.ApplicationSetTemplate{
+					ObjectMeta: metav1.ObjectMeta{Name: "static-app"},
+					Spec: argov1alpha1.ApplicationSpec{
+						Source:      argov1alpha1.ApplicationSource{RepoURL: "https://git.example.com/repo.git", Path: "guestbook"},
+						Destination: argov1alpha1.ApplicationDestination{Server: "https://kubernetes.default.svc"},
+						Project:     "default",
+					},
+				},
+			},
+		}
+
+		scheme := newTestScheme(t)
+		k8sClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(appSet).
+			WithStatusSubresource(&argoprojiov1alpha1.ApplicationSet{}).
+			Build()
+
+		r := &ApplicationSetReconciler{Client: k8sClient, Scheme: scheme, K8sClient: k8sfake.NewSimpleClientset()}
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "my-appset", Namespace: "argocd"}})
+		assert.NoError(t, err)
+		assert.Equal(t, generatorErrorRequeueInterval, result.RequeueAfter)
+
+		var updated argoprojiov1alpha1.ApplicationSet
+		assert.NoError(t, k8sClient.Get(context.Background(), types.NamespacedName{Name: "my-appset", Namespace: "argocd"}, &updated))
+		assert.Len(t, updated.Status.Conditions, 1)
+		assert.Equal(t, argoprojiov1alpha1.ApplicationSetConditionErrorOccurred, updated.Status.Conditions[0].Type)
+
+		var app argov1alpha1.Application
+		err = k8sClient.Get(context.Background(), types.NamespacedName{Name: "static-app", Namespace: "argocd"}, &app)
+		assert.True(t, apierrors.IsNotFound(err))
+	})
+
+	t.Run("missing ApplicationSet is not an error", func(t *testing.T) {
+		scheme := newTestScheme(t)
+		k8sClient := fake.NewClientBuilder().WithScheme(scheme).Build()
+		r := &ApplicationSetReconciler{Client: k8sClient, Scheme: scheme, K8sClient: k8sfake.NewSimpleClientset()}
+
+		result, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: types.NamespacedName{Name: "missing", Namespace: "argocd"}})
+		assert.NoError(t, err)
+		assert.Equal(t, ctrl.Result{}, result)
+	})
+}
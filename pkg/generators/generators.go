@@ -0,0 +1,44 @@
+package generators
+
+import (
+	"fmt"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	"github.com/argoproj-labs/applicationset/pkg/utils"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Generator generates the template parameters for one entry of an ApplicationSet's generators list.
+// Params may be arbitrarily nested (e.g. a cluster generator emitting its labels/annotations as
+// nested maps); callers should filter them with Transform, which matches the selector against a
+// flattened copy and hands the params back still nested, before rendering a template.
+type Generator interface {
+	GenerateParams(generator *argoprojiov1alpha1.ApplicationSetGenerator) ([]map[string]interface{}, error)
+}
+
+// Transform drops any entry of paramsList that doesn't match generator.Selector and returns the
+// survivors unchanged, still arbitrarily nested. Selector matching is done against a flattened copy
+// of each entry so that a selector can target nested fields a generator emits, such as
+// metadata.labels.env - something a cluster generator's labels map could not be filtered by before -
+// but the nested structure itself is preserved for the caller, so a Go-template ApplicationSet can
+// still traverse it with dotted field access (e.g. {{ .cluster.metadata.labels.env }}).
+func Transform(generator argoprojiov1alpha1.ApplicationSetGenerator, paramsList []map[string]interface{}) ([]map[string]interface{}, error) {
+	selector, err := metav1.LabelSelectorAsSelector(generator.Selector)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generator selector: %w", err)
+	}
+
+	result := make([]map[string]interface{}, 0, len(paramsList))
+	for _, params := range paramsList {
+		flat, err := utils.FlattenParameters(params, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to flatten generator params: %w", err)
+		}
+		if !selector.Matches(labels.Set(flat)) {
+			continue
+		}
+		result = append(result, params)
+	}
+	return result, nil
+}
@@ -0,0 +1,75 @@
+package generators
+
+import (
+	"testing"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestTransform(t *testing.T) {
+
+	paramsList := []map[string]interface{}{
+		{
+			"name": "prod-cluster",
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"env": "prod",
+				},
+			},
+		},
+		{
+			"name": "staging-cluster",
+			"metadata": map[string]interface{}{
+				"labels": map[string]interface{}{
+					"env": "staging",
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		testName      string
+		selector      *metav1.LabelSelector
+		expectedNames []string
+	}{
+		{
+			testName:      "no selector matches everything",
+			selector:      nil,
+			expectedNames: []string{"prod-cluster", "staging-cluster"},
+		},
+		{
+			testName: "selector matches a flattened nested label",
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"metadata.labels.env": "prod"},
+			},
+			expectedNames: []string{"prod-cluster"},
+		},
+		{
+			testName: "selector matching nothing returns no params",
+			selector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"metadata.labels.env": "canary"},
+			},
+			expectedNames: nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.testName, func(t *testing.T) {
+			generator := argoprojiov1alpha1.ApplicationSetGenerator{
+				Clusters: &argoprojiov1alpha1.ClusterGenerator{},
+				Selector: test.selector,
+			}
+
+			result, err := Transform(generator, paramsList)
+			assert.NoError(t, err)
+
+			var names []string
+			for _, params := range result {
+				names = append(names, params["name"].(string))
+			}
+			assert.Equal(t, test.expectedNames, names)
+		})
+	}
+}
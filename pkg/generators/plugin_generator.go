@@ -0,0 +1,54 @@
+package generators
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	"github.com/argoproj-labs/applicationset/pkg/services/plugin"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PluginGenerator generates params by calling out to an externally hosted plugin over HTTP.
+type PluginGenerator struct {
+	k8sClient kubernetes.Interface
+	namespace string
+}
+
+// NewPluginGenerator returns a Generator that resolves each PluginGenerator's endpoint from a Secret
+// in namespace.
+func NewPluginGenerator(k8sClient kubernetes.Interface, namespace string) *PluginGenerator {
+	return &PluginGenerator{k8sClient: k8sClient, namespace: namespace}
+}
+
+// GenerateParams calls the plugin named by generator.Plugin and returns its params, each merged with
+// generator.Plugin.Values (which take precedence over anything the plugin itself returned).
+func (g *PluginGenerator) GenerateParams(generator *argoprojiov1alpha1.ApplicationSetGenerator) ([]map[string]interface{}, error) {
+	if generator.Plugin == nil {
+		return nil, fmt.Errorf("plugin generator is not configured")
+	}
+
+	ctx := context.Background()
+	config, err := plugin.ResolveConfig(ctx, g.k8sClient, g.namespace, generator.Plugin.ConfigRef)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve plugin config: %w", err)
+	}
+	if generator.Plugin.RequestTimeout > 0 {
+		config.Timeout = time.Duration(generator.Plugin.RequestTimeout) * time.Second
+	}
+
+	service := plugin.NewService(config)
+	paramsList, err := service.Generate(ctx, "", g.namespace, *generator.Plugin)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate params from plugin %q: %w", generator.Plugin.Name, err)
+	}
+
+	for _, params := range paramsList {
+		for k, v := range generator.Plugin.Values {
+			params[k] = v
+		}
+	}
+
+	return paramsList, nil
+}
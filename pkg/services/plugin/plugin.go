@@ -0,0 +1,187 @@
+// Package plugin implements the HTTP contract used by the Plugin generator to fetch template
+// parameters from an externally hosted service.
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultTimeout    = 30 * time.Second
+	defaultMaxRetries = 3
+)
+
+// request is the JSON body POSTed to the plugin endpoint.
+type request struct {
+	ApplicationSetName string       `json:"applicationSetName"`
+	Namespace          string       `json:"namespace"`
+	Plugin             string       `json:"plugin"`
+	Input              requestInput `json:"input"`
+}
+
+type requestInput struct {
+	Parameters map[string]string `json:"parameters,omitempty"`
+}
+
+// response is the expected shape of a successful plugin response.
+type response struct {
+	Output responseOutput `json:"output"`
+}
+
+type responseOutput struct {
+	Parameters []map[string]interface{} `json:"parameters"`
+}
+
+// Config holds what's needed to reach a plugin endpoint, as resolved from the Secret referenced by a
+// PluginGenerator's ConfigRef.
+type Config struct {
+	BaseURL            string
+	Token              string
+	Timeout            time.Duration
+	InsecureSkipVerify bool
+	MaxRetries         int
+}
+
+// ResolveConfig resolves a PluginGenerator's endpoint URL and bearer token from the Secret named by
+// ref, in namespace. The secret is expected to store the endpoint under the "baseUrl" key and the
+// (optional) token under the "token" key.
+func ResolveConfig(ctx context.Context, k8sClient kubernetes.Interface, namespace string, ref argoprojiov1alpha1.PluginConfigMapRef) (Config, error) {
+	secret, err := k8sClient.CoreV1().Secrets(namespace).Get(ctx, ref.Name, metav1.GetOptions{})
+	if err != nil {
+		return Config{}, fmt.Errorf("failed to read plugin config secret %q: %w", ref.Name, err)
+	}
+	config := configFromSecret(secret)
+	config.InsecureSkipVerify = ref.InsecureSkipVerify
+	return config, nil
+}
+
+func configFromSecret(secret *corev1.Secret) Config {
+	return Config{
+		BaseURL: string(secret.Data["baseUrl"]),
+		Token:   string(secret.Data["token"]),
+	}
+}
+
+// Service talks to a single plugin endpoint over HTTP.
+type Service struct {
+	config Config
+	client *http.Client
+}
+
+// NewService builds a Service ready to call the endpoint described by config.
+func NewService(config Config) *Service {
+	if config.Timeout == 0 {
+		config.Timeout = defaultTimeout
+	}
+	if config.MaxRetries == 0 {
+		config.MaxRetries = defaultMaxRetries
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{InsecureSkipVerify: config.InsecureSkipVerify}
+
+	return &Service{
+		config: config,
+		client: &http.Client{Timeout: config.Timeout, Transport: transport},
+	}
+}
+
+// Generate POSTs one request to the plugin, retrying with backoff up to MaxRetries times when it
+// answers with a 5xx status, and returns one params map per entry of its response's
+// output.parameters. A response entry is used as-is (it is never interpreted as a
+// template or evaluated) and is only later substituted into an Application template by Render, so
+// user-controlled parameter values cannot inject anything beyond a literal string value.
+func (s *Service) Generate(ctx context.Context, appSetName, namespace string, generator argoprojiov1alpha1.PluginGenerator) ([]map[string]interface{}, error) {
+	body, err := json.Marshal(request{
+		ApplicationSetName: appSetName,
+		Namespace:          namespace,
+		Plugin:             generator.Name,
+		Input:              requestInput{Parameters: generator.Input.Parameters},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal plugin request: %w", err)
+	}
+
+	var lastErr error
+	for attempt := 0; attempt <= s.config.MaxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-time.After(backoff(attempt)):
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		params, err := s.call(ctx, body)
+		if err == nil {
+			return params, nil
+		}
+		lastErr = err
+		if !isRetryable(err) {
+			break
+		}
+	}
+	return nil, fmt.Errorf("plugin %q: %w", s.config.BaseURL, lastErr)
+}
+
+func (s *Service) call(ctx context.Context, body []byte) ([]map[string]interface{}, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.BaseURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build plugin request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.config.Token)
+	}
+
+	httpResp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read plugin response: %w", err)
+	}
+
+	switch {
+	case httpResp.StatusCode == http.StatusUnauthorized || httpResp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("authentication failed: %s", httpResp.Status)
+	case httpResp.StatusCode >= http.StatusInternalServerError:
+		return nil, retryableError{fmt.Errorf("received %s", httpResp.Status)}
+	case httpResp.StatusCode != http.StatusOK:
+		return nil, fmt.Errorf("unexpected status %s", httpResp.Status)
+	}
+
+	var parsed response
+	if err := json.Unmarshal(respBody, &parsed); err != nil {
+		return nil, fmt.Errorf("failed to parse plugin response: %w", err)
+	}
+
+	return parsed.Output.Parameters, nil
+}
+
+// retryableError marks an error as eligible for Generate's backoff-and-retry loop.
+type retryableError struct{ error }
+
+func isRetryable(err error) bool {
+	_, ok := err.(retryableError)
+	return ok
+}
+
+func backoff(attempt int) time.Duration {
+	return time.Duration(attempt) * 500 * time.Millisecond
+}
@@ -0,0 +1,123 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestResolveConfig(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "my-plugin-config", Namespace: "argocd"},
+		Data: map[string][]byte{
+			"baseUrl": []byte("https://plugin.example.com"),
+			"token":   []byte("my-token"),
+		},
+	}
+	k8sClient := fake.NewSimpleClientset(secret)
+
+	t.Run("insecureSkipVerify defaults to false", func(t *testing.T) {
+		config, err := ResolveConfig(context.Background(), k8sClient, "argocd", argoprojiov1alpha1.PluginConfigMapRef{Name: "my-plugin-config"})
+		assert.NoError(t, err)
+		assert.False(t, config.InsecureSkipVerify)
+	})
+
+	t.Run("insecureSkipVerify is carried through from the ref", func(t *testing.T) {
+		config, err := ResolveConfig(context.Background(), k8sClient, "argocd", argoprojiov1alpha1.PluginConfigMapRef{
+			Name:               "my-plugin-config",
+			InsecureSkipVerify: true,
+		})
+		assert.NoError(t, err)
+		assert.True(t, config.InsecureSkipVerify)
+	})
+}
+
+func TestServiceGenerate(t *testing.T) {
+
+	t.Run("success", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "Bearer my-token", r.Header.Get("Authorization"))
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = w.Write([]byte(`{"output":{"parameters":[{"name":"a"},{"name":"b"}]}}`))
+		}))
+		defer server.Close()
+
+		service := NewService(Config{BaseURL: server.URL, Token: "my-token"})
+		params, err := service.Generate(context.Background(), "my-appset", "argocd", argoprojiov1alpha1.PluginGenerator{Name: "my-plugin"})
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{{"name": "a"}, {"name": "b"}}, params)
+	})
+
+	t.Run("auth failure is not retried", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		service := NewService(Config{BaseURL: server.URL})
+		_, err := service.Generate(context.Background(), "my-appset", "argocd", argoprojiov1alpha1.PluginGenerator{Name: "my-plugin"})
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("malformed JSON output", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`not json`))
+		}))
+		defer server.Close()
+
+		service := NewService(Config{BaseURL: server.URL})
+		_, err := service.Generate(context.Background(), "my-appset", "argocd", argoprojiov1alpha1.PluginGenerator{Name: "my-plugin"})
+		assert.Error(t, err)
+	})
+
+	t.Run("retries on 5xx then succeeds", func(t *testing.T) {
+		calls := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls++
+			if calls < 2 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			_, _ = w.Write([]byte(`{"output":{"parameters":[{"name":"a"}]}}`))
+		}))
+		defer server.Close()
+
+		service := NewService(Config{BaseURL: server.URL, MaxRetries: 2})
+		params, err := service.Generate(context.Background(), "my-appset", "argocd", argoprojiov1alpha1.PluginGenerator{Name: "my-plugin"})
+		assert.NoError(t, err)
+		assert.Equal(t, []map[string]interface{}{{"name": "a"}}, params)
+		assert.Equal(t, 2, calls)
+	})
+
+	t.Run("parameter values containing template-like syntax pass through verbatim", func(t *testing.T) {
+		var receivedBody string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			buf, _ := io.ReadAll(r.Body)
+			receivedBody = string(buf)
+			_, _ = w.Write([]byte(`{"output":{"parameters":[{"name":"{{ evil }}; rm -rf /"}]}}`))
+		}))
+		defer server.Close()
+
+		service := NewService(Config{BaseURL: server.URL})
+		input := map[string]string{"user": "{{ evil }}; rm -rf /"}
+		params, err := service.Generate(context.Background(), "my-appset", "argocd", argoprojiov1alpha1.PluginGenerator{
+			Name:  "my-plugin",
+			Input: argoprojiov1alpha1.PluginInput{Parameters: input},
+		})
+		assert.NoError(t, err)
+		assert.Contains(t, receivedBody, fmt.Sprintf(`%q`, input["user"]))
+		assert.Equal(t, "{{ evil }}; rm -rf /", params[0]["name"])
+	})
+}
@@ -0,0 +1,100 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FlattenParameters recursively walks params - which may contain nested maps and arrays, as produced
+// by generators that emit structured data (e.g. a cluster generator's labels/annotations) - and
+// returns a flat map[string]string with dotted keys, e.g. metadata.labels.env=prod. This is the key
+// space that ApplicationSetGenerator.Selector matches against, and that legacy `{{a.b.c}}` template
+// tags look up in RenderTemplateParams.
+//
+// Arrays are flattened with an index-suffixed key (tags.0=foo, tags.1=bar) unless encodeArraysAsJSON
+// is true, in which case the whole array is JSON-encoded into a single leaf value instead.
+//
+// It is an error for a key to be both a leaf value and the prefix of a sub-tree, since there would be
+// no single consistent flattened value for it.
+func FlattenParameters(params map[string]interface{}, encodeArraysAsJSON bool) (map[string]string, error) {
+	f := &flattener{
+		flat:             make(map[string]string),
+		branches:         make(map[string]bool),
+		jsonEncodeArrays: encodeArraysAsJSON,
+	}
+	if err := f.walk("", params); err != nil {
+		return nil, err
+	}
+	return f.flat, nil
+}
+
+type flattener struct {
+	flat             map[string]string
+	branches         map[string]bool
+	jsonEncodeArrays bool
+}
+
+func (f *flattener) walk(prefix string, value interface{}) error {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if err := f.markBranch(prefix); err != nil {
+			return err
+		}
+		for key, child := range v {
+			if err := f.walk(joinKey(prefix, key), child); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case []interface{}:
+		if f.jsonEncodeArrays {
+			encoded, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Errorf("failed to JSON-encode array parameter %q: %w", prefix, err)
+			}
+			return f.setLeaf(prefix, string(encoded))
+		}
+		if err := f.markBranch(prefix); err != nil {
+			return err
+		}
+		for i, child := range v {
+			if err := f.walk(fmt.Sprintf("%s.%d", prefix, i), child); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	default:
+		return f.setLeaf(prefix, fmt.Sprintf("%v", v))
+	}
+}
+
+func (f *flattener) markBranch(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	if _, ok := f.flat[prefix]; ok {
+		return fmt.Errorf("parameter key collision: %q is both a leaf value and a sub-tree", prefix)
+	}
+	f.branches[prefix] = true
+	return nil
+}
+
+func (f *flattener) setLeaf(key string, value string) error {
+	if f.branches[key] {
+		return fmt.Errorf("parameter key collision: %q is both a leaf value and a sub-tree", key)
+	}
+	if _, exists := f.flat[key]; exists {
+		return fmt.Errorf("parameter key collision: %q is set by more than one field", key)
+	}
+	f.flat[key] = value
+	return nil
+}
+
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,112 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlattenParameters(t *testing.T) {
+	tests := []struct {
+		name        string
+		params      map[string]interface{}
+		jsonArrays  bool
+		expected    map[string]string
+		expectedErr bool
+	}{
+		{
+			name: "nested maps",
+			params: map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"env": "prod",
+					},
+					"annotations": map[string]interface{}{
+						"team": "payments",
+					},
+				},
+			},
+			expected: map[string]string{
+				"metadata.labels.env":       "prod",
+				"metadata.annotations.team": "payments",
+			},
+		},
+		{
+			name: "array of scalars, index-suffixed",
+			params: map[string]interface{}{
+				"tags": []interface{}{"foo", "bar"},
+			},
+			expected: map[string]string{
+				"tags.0": "foo",
+				"tags.1": "bar",
+			},
+		},
+		{
+			name: "array of scalars, JSON-encoded",
+			params: map[string]interface{}{
+				"tags": []interface{}{"foo", "bar"},
+			},
+			jsonArrays: true,
+			expected: map[string]string{
+				"tags": `["foo","bar"]`,
+			},
+		},
+		{
+			name: "array of maps",
+			params: map[string]interface{}{
+				"containers": []interface{}{
+					map[string]interface{}{"name": "app", "image": "nginx"},
+					map[string]interface{}{"name": "sidecar", "image": "envoy"},
+				},
+			},
+			expected: map[string]string{
+				"containers.0.name":  "app",
+				"containers.0.image": "nginx",
+				"containers.1.name":  "sidecar",
+				"containers.1.image": "envoy",
+			},
+		},
+		{
+			name: "scalar at top level",
+			params: map[string]interface{}{
+				"name": "prod-cluster",
+			},
+			expected: map[string]string{
+				"name": "prod-cluster",
+			},
+		},
+		{
+			name: "empty string key is a legitimate leaf, not a root marker",
+			params: map[string]interface{}{
+				"":  "three",
+				" ": "two",
+			},
+			expected: map[string]string{
+				"":  "three",
+				" ": "two",
+			},
+		},
+		{
+			name: "key collision between a leaf and a sub-tree",
+			params: map[string]interface{}{
+				"a": map[string]interface{}{
+					"b": "x",
+				},
+				"a.b": "y",
+			},
+			expectedErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := FlattenParameters(test.params, test.jsonArrays)
+			if test.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+}
@@ -0,0 +1,463 @@
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+
+	sprig "github.com/Masterminds/sprig/v3"
+	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
+	argov1alpha1 "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"sigs.k8s.io/yaml"
+)
+
+const kubectlLastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+// Render is capable of rendering the parameters for, and patching, a templated Application.
+type Render struct {
+	// GoTemplate switches RenderTemplateParams from the legacy literal `{{key}}` substitution to
+	// Go's text/template, with the Sprig function library registered. Defaults to false so existing
+	// ApplicationSets keep their current behavior.
+	GoTemplate bool
+
+	// GoTemplateOptions is passed verbatim to text/template's Option, e.g. []string{"missingkey=error"}.
+	// Only used when GoTemplate is true.
+	GoTemplateOptions []string
+}
+
+// reTemplated matches a `{{ key }}` style template tag, tolerating surrounding whitespace. The key
+// itself may not contain whitespace or braces, so a tag containing only whitespace (e.g. `{{ }}`)
+// is deliberately never matched.
+var reTemplated = regexp.MustCompile(`{{\s*([^{}\s]+)\s*}}`)
+
+// replaceString substitutes every `{{ key }}` occurrence in val with params[key]. Tags whose key has
+// no corresponding entry in params are left untouched.
+func replaceString(val string, params map[string]string) string {
+	if val == "" {
+		return val
+	}
+	return reTemplated.ReplaceAllStringFunc(val, func(tag string) string {
+		key := reTemplated.FindStringSubmatch(tag)[1]
+		if replacement, ok := params[key]; ok {
+			return replacement
+		}
+		return tag
+	})
+}
+
+// renderParams bundles a generator's params in both of the forms RenderTemplateParams needs: nested,
+// so a Go template can reach structured data with dotted field access (e.g.
+// {{ .cluster.metadata.labels.env }}), and flattened with dotted keys, for the legacy literal
+// `{{key}}` substitution, which only ever understood flat string values.
+type renderParams struct {
+	nested map[string]interface{}
+	flat   map[string]string
+}
+
+func newRenderParams(params map[string]interface{}) (renderParams, error) {
+	flat, err := FlattenParameters(params, false)
+	if err != nil {
+		return renderParams{}, fmt.Errorf("failed to flatten params: %w", err)
+	}
+	return renderParams{nested: params, flat: flat}, nil
+}
+
+// RenderTemplateParams renders the templatable fields of an Application generated from an
+// ApplicationSet template - metadata.name and the spec fields listed on renderApplicationSpec - using
+// the given params, and returns the result. Other fields, such as metadata labels/annotations or the
+// sources' helm/kustomize settings, are copied without rendering. When r.GoTemplate is false (the default),
+// fields are rendered with the legacy literal `{{key}}` substitution against a flattened, dotted-key
+// copy of params. When true, fields are rendered with Go's text/template, with Sprig functions
+// registered and params - still nested - exposed as the template's root data. It also ensures the
+// returned Application carries the correct finalizers, based on syncPolicy and any finalizers already
+// present on tmpl. If params is nil, tmpl is returned as-is, without rendering or finalizer changes.
+func (r *Render) RenderTemplateParams(tmpl *argov1alpha1.Application, syncPolicy *argoprojiov1alpha1.ApplicationSetSyncPolicy, params map[string]interface{}) (*argov1alpha1.Application, error) {
+	if tmpl == nil {
+		return nil, fmt.Errorf("application template is empty")
+	}
+
+	if params == nil {
+		return tmpl, nil
+	}
+
+	result := tmpl.DeepCopy()
+
+	rp, err := newRenderParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to render application %q: %w", tmpl.Name, err)
+	}
+
+	if result.Name, err = r.render(result.Name, rp); err != nil {
+		return nil, fmt.Errorf("failed to render application %q: %w", tmpl.Name, err)
+	}
+
+	if err := r.renderApplicationSpec(&result.Spec, rp); err != nil {
+		return nil, fmt.Errorf("failed to render application %q: %w", tmpl.Name, err)
+	}
+
+	result.Finalizers = resolveFinalizers(tmpl.Finalizers, syncPolicy)
+
+	return result, nil
+}
+
+// renderApplicationSpec renders in place the path, repoURL, targetRevision and chart of the source and
+// of each entry of sources; the destination's server, namespace and name; the project; the sync
+// policy's syncOptions; and the name and value of each info entry. No other field of spec is rendered.
+func (r *Render) renderApplicationSpec(spec *argov1alpha1.ApplicationSpec, params renderParams) error {
+	var err error
+
+	if spec.Source.Path, err = r.render(spec.Source.Path, params); err != nil {
+		return err
+	}
+	if spec.Source.RepoURL, err = r.render(spec.Source.RepoURL, params); err != nil {
+		return err
+	}
+	if spec.Source.TargetRevision, err = r.render(spec.Source.TargetRevision, params); err != nil {
+		return err
+	}
+	if spec.Source.Chart, err = r.render(spec.Source.Chart, params); err != nil {
+		return err
+	}
+
+	for i := range spec.Sources {
+		source := &spec.Sources[i]
+		if source.Path, err = r.render(source.Path, params); err != nil {
+			return err
+		}
+		if source.RepoURL, err = r.render(source.RepoURL, params); err != nil {
+			return err
+		}
+		if source.TargetRevision, err = r.render(source.TargetRevision, params); err != nil {
+			return err
+		}
+		if source.Chart, err = r.render(source.Chart, params); err != nil {
+			return err
+		}
+	}
+
+	if spec.Destination.Server, err = r.render(spec.Destination.Server, params); err != nil {
+		return err
+	}
+	if spec.Destination.Namespace, err = r.render(spec.Destination.Namespace, params); err != nil {
+		return err
+	}
+	if spec.Destination.Name, err = r.render(spec.Destination.Name, params); err != nil {
+		return err
+	}
+
+	if spec.Project, err = r.render(spec.Project, params); err != nil {
+		return err
+	}
+
+	if spec.SyncPolicy != nil {
+		for i, option := range spec.SyncPolicy.SyncOptions {
+			if spec.SyncPolicy.SyncOptions[i], err = r.render(option, params); err != nil {
+				return err
+			}
+		}
+	}
+
+	for i := range spec.Info {
+		if spec.Info[i].Name, err = r.render(spec.Info[i].Name, params); err != nil {
+			return err
+		}
+		if spec.Info[i].Value, err = r.render(spec.Info[i].Value, params); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// render renders a single string field, dispatching to the Go template engine or the legacy literal
+// substitution depending on r.GoTemplate.
+func (r *Render) render(val string, params renderParams) (string, error) {
+	if !r.GoTemplate {
+		return replaceString(val, params.flat), nil
+	}
+	return r.renderGoTemplate(val, params.nested)
+}
+
+// renderGoTemplate parses and executes val as a Go text/template, with Sprig functions registered and
+// params - still nested, so structured generator output can be traversed with dotted field access -
+// exposed as the root data.
+func (r *Render) renderGoTemplate(val string, params map[string]interface{}) (string, error) {
+	if val == "" {
+		return val, nil
+	}
+
+	tmpl := template.New("applicationset-template").Funcs(sprig.TxtFuncMap())
+	for _, option := range r.GoTemplateOptions {
+		tmpl = tmpl.Option(option)
+	}
+
+	parsed, err := tmpl.Parse(val)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse template %q: %w", val, err)
+	}
+
+	var buf strings.Builder
+	if err := parsed.Execute(&buf, params); err != nil {
+		return "", fmt.Errorf("failed to execute template %q: %w", val, err)
+	}
+
+	return buf.String(), nil
+}
+
+// resolveFinalizers preserves any finalizers already present on the generated Application. Otherwise,
+// it adds the standard Argo CD resources-finalizer, unless the ApplicationSet has opted out via
+// syncPolicy.preserveResourcesOnDeletion.
+func resolveFinalizers(existing []string, syncPolicy *argoprojiov1alpha1.ApplicationSetSyncPolicy) []string {
+	if len(existing) > 0 {
+		return existing
+	}
+	if syncPolicy != nil && syncPolicy.PreserveResourcesOnDeletion {
+		return nil
+	}
+	return []string{argov1alpha1.ResourcesFinalizerName}
+}
+
+// ApplyTemplatePatch renders params into patchTemplate using the same substitution engine as
+// RenderTemplateParams, then applies the result on top of app as a Kubernetes strategic merge patch
+// (which, for fields with no patchStrategy, behaves the same as a JSON merge patch, and additionally
+// merges lists for tagged fields such as metadata.finalizers; untagged lists such as spec.sources are
+// replaced wholesale). Top-level fields that
+// render to an empty value are dropped from the patch first, so a templated `if` around an optional
+// section (e.g. syncPolicy) doesn't get misread as an explicit instruction to clear that field.
+func (r *Render) ApplyTemplatePatch(app *argov1alpha1.Application, patchTemplate string, params map[string]interface{}) (*argov1alpha1.Application, error) {
+	if app == nil {
+		return nil, fmt.Errorf("application template is empty")
+	}
+	if strings.TrimSpace(patchTemplate) == "" {
+		return app, nil
+	}
+
+	rp, err := newRenderParams(params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply templatePatch to Application %q: %w", app.Name, err)
+	}
+
+	renderedPatch, err := r.render(patchTemplate, rp)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply templatePatch to Application %q: %w", app.Name, err)
+	}
+
+	patchJSON, err := yaml.YAMLToJSON([]byte(renderedPatch))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templatePatch as YAML/JSON: %w", err)
+	}
+
+	patchJSON, err = dropEmptyTopLevelFields(patchJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templatePatch as YAML/JSON: %w", err)
+	}
+	if len(patchJSON) == 0 || string(patchJSON) == "null" {
+		return app, nil
+	}
+
+	appJSON, err := json.Marshal(app)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal Application %q: %w", app.Name, err)
+	}
+
+	patchMeta, err := strategicpatch.NewPatchMetaFromStruct(reflect.TypeOf(argov1alpha1.Application{}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read patch metadata for Application: %w", err)
+	}
+
+	mergedJSON, err := strategicpatch.StrategicMergePatchUsingLookupPatchMeta(appJSON, patchJSON, patchMeta)
+	if err != nil {
+		return nil, fmt.Errorf("failed to apply templatePatch to Application %q: %w", app.Name, err)
+	}
+
+	patched := &argov1alpha1.Application{}
+	if err := json.Unmarshal(mergedJSON, patched); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal patched Application %q: %w", app.Name, err)
+	}
+
+	return patched, nil
+}
+
+// dropEmptyTopLevelFields removes any object/array/string field of patchJSON whose rendered value is
+// empty, at any depth. This lets a templatePatch conditionally include a section (e.g. `{{ if ... }}`
+// around syncPolicy) without that section's absence being treated as "clear this field" - including
+// when the empty section is nested under another field, like `spec.syncPolicy`.
+func dropEmptyTopLevelFields(patchJSON []byte) ([]byte, error) {
+	var fields map[string]interface{}
+	if err := json.Unmarshal(patchJSON, &fields); err != nil {
+		return nil, err
+	}
+	return json.Marshal(dropEmptyFields(fields))
+}
+
+// dropEmptyFields recursively strips empty string/map/slice values from the maps in value, returning
+// the result. A map left empty after its own children are stripped is itself considered empty, so
+// emptiness propagates up from however deep the `{{ if ... }}` sits. Slice elements are cleaned
+// recursively but never removed, so a slice is only dropped when it was empty to begin with.
+func dropEmptyFields(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			child = dropEmptyFields(child)
+			if isEmptyField(child) {
+				delete(v, key)
+			} else {
+				v[key] = child
+			}
+		}
+		return v
+	case []interface{}:
+		for i, child := range v {
+			v[i] = dropEmptyFields(child)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// isEmptyField reports whether value is a nil, empty string, empty map, or empty slice - the shapes
+// dropEmptyFields treats as "this field was never rendered" rather than "explicitly set to zero".
+func isEmptyField(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case map[string]interface{}:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// GeneratorResult is the outcome of running a single entry of an ApplicationSet's generators list:
+// either the params it produced, or the error it failed with.
+type GeneratorResult struct {
+	// Index is the position of this generator within ApplicationSetSpec.Generators.
+	Index int
+	// Params holds the params produced by the generator, one entry per Application to generate, still
+	// arbitrarily nested. Empty when Err is set.
+	Params []map[string]interface{}
+	// Err is the error the generator failed with, or nil on success.
+	Err error
+}
+
+// GeneratorError describes a single generator that failed, in a form suitable for surfacing on
+// ApplicationSet.status.conditions.
+type GeneratorError struct {
+	// Index is the position of the failed generator within ApplicationSetSpec.Generators.
+	Index int
+	// Message is the human-readable reason the generator failed.
+	Message string
+}
+
+func (e GeneratorError) Error() string {
+	return fmt.Sprintf("generator at index %d failed: %s", e.Index, e.Message)
+}
+
+// PartitionGeneratorResults splits results into the params produced by generators that succeeded and
+// the errors recorded by the ones that failed. This lets a single broken generator (an empty
+// generator entry, a plugin timeout, an unreachable git repo) keep the ApplicationSet's other,
+// healthy generators from being blocked - their Applications are still created/updated, while the
+// failures are reported separately (e.g. as status conditions).
+func PartitionGeneratorResults(results []GeneratorResult) ([]map[string]interface{}, []GeneratorError) {
+	var good []map[string]interface{}
+	var errs []GeneratorError
+
+	for _, result := range results {
+		if result.Err != nil {
+			errs = append(errs, GeneratorError{Index: result.Index, Message: result.Err.Error()})
+			continue
+		}
+		good = append(good, result.Params...)
+	}
+
+	return good, errs
+}
+
+// CheckInvalidGenerators logs a warning if the ApplicationSet contains any generators that this
+// controller does not recognize, for example because the ApplicationSet was created or updated by a
+// newer version of the controller that supports additional generator types.
+func CheckInvalidGenerators(appSet *argoprojiov1alpha1.ApplicationSet) {
+	hasInvalidGenerators, names := invalidGenerators(appSet)
+	if !hasInvalidGenerators {
+		return
+	}
+
+	msg := fmt.Sprintf("ApplicationSet %s contains unrecognized generators", appSet.Name)
+	if len(names) > 0 {
+		sortedNames := make([]string, 0, len(names))
+		for name := range names {
+			sortedNames = append(sortedNames, name)
+		}
+		sort.Strings(sortedNames)
+		msg = fmt.Sprintf("%s: %s", msg, strings.Join(sortedNames, ", "))
+	}
+	logrus.Warn(msg)
+}
+
+// invalidGenerators returns whether the ApplicationSet contains any unrecognized generators, along
+// with the best-effort set of generator names it was able to recover for those entries from the
+// kubectl.kubernetes.io/last-applied-configuration annotation (client-side apply drops unrecognized
+// fields before they reach the live object, so the annotation is the only place the original
+// generator name may still be found).
+func invalidGenerators(appSet *argoprojiov1alpha1.ApplicationSet) (bool, map[string]bool) {
+	hasInvalidGenerators := false
+	names := make(map[string]bool)
+
+	annotatedNames := lastAppliedGeneratorNames(appSet)
+
+	for index, generator := range appSet.Spec.Generators {
+		if isRecognizedGenerator(generator) {
+			continue
+		}
+		hasInvalidGenerators = true
+		if index < len(annotatedNames) && annotatedNames[index] != "" {
+			names[annotatedNames[index]] = true
+		}
+	}
+
+	return hasInvalidGenerators, names
+}
+
+func isRecognizedGenerator(generator argoprojiov1alpha1.ApplicationSetGenerator) bool {
+	return generator.List != nil || generator.Clusters != nil || generator.Git != nil || generator.Plugin != nil
+}
+
+// lastAppliedGeneratorNames returns, for each generator position in the last-applied-configuration
+// annotation (if present and parseable), the name of the key used for that generator (e.g. "list",
+// "cluster", or an unrecognized name such as "aaa"). It returns nil if the annotation is absent or
+// malformed.
+func lastAppliedGeneratorNames(appSet *argoprojiov1alpha1.ApplicationSet) []string {
+	annotation, ok := appSet.Annotations[kubectlLastAppliedAnnotation]
+	if !ok {
+		return nil
+	}
+
+	var parsed struct {
+		Spec struct {
+			Generators []map[string]json.RawMessage `json:"generators"`
+		} `json:"spec"`
+	}
+	if err := json.Unmarshal([]byte(annotation), &parsed); err != nil {
+		return nil
+	}
+
+	names := make([]string, len(parsed.Spec.Generators))
+	for i, generator := range parsed.Spec.Generators {
+		for name := range generator {
+			names[i] = name
+			break
+		}
+	}
+	return names
+}
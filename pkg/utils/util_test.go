@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"fmt"
 	"testing"
 
 	argoprojiov1alpha1 "github.com/argoproj-labs/applicationset/api/v1alpha1"
@@ -47,14 +48,14 @@ func TestRenderTemplateParams(t *testing.T) {
 	tests := []struct {
 		name        string
 		fieldVal    string
-		params      map[string]string
+		params      map[string]interface{}
 		expectedVal string
 	}{
 		{
 			name:        "simple substitution",
 			fieldVal:    "{{one}}",
 			expectedVal: "two",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"one": "two",
 			},
 		},
@@ -62,7 +63,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "simple substitution with whitespace",
 			fieldVal:    "{{ one }}",
 			expectedVal: "two",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"one": "two",
 			},
 		},
@@ -71,7 +72,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "template characters but not in a template",
 			fieldVal:    "}} {{",
 			expectedVal: "}} {{",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"one": "two",
 			},
 		},
@@ -80,7 +81,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "nested template",
 			fieldVal:    "{{ }}",
 			expectedVal: "{{ }}",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"one": "{{ }}",
 			},
 		},
@@ -88,7 +89,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "field with whitespace",
 			fieldVal:    "{{ }}",
 			expectedVal: "{{ }}",
-			params: map[string]string{
+			params: map[string]interface{}{
 				" ": "two",
 				"":  "three",
 			},
@@ -98,7 +99,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "template contains itself, containing itself",
 			fieldVal:    "{{one}}",
 			expectedVal: "{{one}}",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"{{one}}": "{{one}}",
 			},
 		},
@@ -107,7 +108,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "template contains itself, containing something else",
 			fieldVal:    "{{one}}",
 			expectedVal: "{{one}}",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"{{one}}": "{{two}}",
 			},
 		},
@@ -116,7 +117,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "templates are case sensitive",
 			fieldVal:    "{{ONE}}",
 			expectedVal: "{{ONE}}",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"{{one}}": "two",
 			},
 		},
@@ -124,7 +125,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "multiple on a line",
 			fieldVal:    "{{one}}{{one}}",
 			expectedVal: "twotwo",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"one": "two",
 			},
 		},
@@ -132,7 +133,7 @@ func TestRenderTemplateParams(t *testing.T) {
 			name:        "multiple different on a line",
 			fieldVal:    "{{one}}{{three}}",
 			expectedVal: "twofour",
-			params: map[string]string{
+			params: map[string]interface{}{
 				"one":   "two",
 				"three": "four",
 			},
@@ -254,7 +255,7 @@ func TestRenderTemplateParamsFinalizers(t *testing.T) {
 			application := emptyApplication.DeepCopy()
 			application.Finalizers = c.existingFinalizers
 
-			params := map[string]string{
+			params := map[string]interface{}{
 				"one": "two",
 			}
 
@@ -272,6 +273,243 @@ func TestRenderTemplateParamsFinalizers(t *testing.T) {
 
 }
 
+func TestRenderTemplateParamsGoTemplate(t *testing.T) {
+
+	emptyApplication := func() *argov1alpha1.Application {
+		return &argov1alpha1.Application{
+			Spec: argov1alpha1.ApplicationSpec{
+				Source: argov1alpha1.ApplicationSource{},
+			},
+		}
+	}
+
+	t.Run("sprig pipeline is applied", func(t *testing.T) {
+		render := Render{GoTemplate: true}
+		app, err := render.RenderTemplateParams(emptyApplication(), nil, map[string]interface{}{
+			"name": "My-App",
+		})
+		assert.NoError(t, err)
+		app.Spec.Source.Path = "{{ .name | trunc 63 | lower }}"
+		app, err = render.RenderTemplateParams(app, nil, map[string]interface{}{"name": "My-App"})
+		assert.NoError(t, err)
+		assert.Equal(t, "my-app", app.Spec.Source.Path)
+	})
+
+	t.Run("missing key renders as <no value> by default", func(t *testing.T) {
+		render := Render{GoTemplate: true}
+		app := emptyApplication()
+		app.Spec.Source.Path = "{{ .missing }}"
+		app, err := render.RenderTemplateParams(app, nil, map[string]interface{}{"name": "value"})
+		assert.NoError(t, err)
+		assert.Equal(t, "<no value>", app.Spec.Source.Path)
+	})
+
+	t.Run("missing key errors when missingkey=error is set", func(t *testing.T) {
+		render := Render{GoTemplate: true, GoTemplateOptions: []string{"missingkey=error"}}
+		app := emptyApplication()
+		app.Spec.Source.Path = "{{ .missing }}"
+		_, err := render.RenderTemplateParams(app, nil, map[string]interface{}{"name": "value"})
+		assert.Error(t, err)
+	})
+
+	t.Run("legacy substitution still works when GoTemplate is false", func(t *testing.T) {
+		render := Render{GoTemplate: false}
+		app := emptyApplication()
+		app.Spec.Source.Path = "{{one}}"
+		app, err := render.RenderTemplateParams(app, nil, map[string]interface{}{"one": "two"})
+		assert.NoError(t, err)
+		assert.Equal(t, "two", app.Spec.Source.Path)
+	})
+
+	t.Run("nested params are reachable by dotted field access", func(t *testing.T) {
+		render := Render{GoTemplate: true}
+		app := emptyApplication()
+		app.Spec.Source.Path = "{{ .cluster.metadata.labels.env }}"
+		app, err := render.RenderTemplateParams(app, nil, map[string]interface{}{
+			"cluster": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"labels": map[string]interface{}{
+						"env": "prod",
+					},
+				},
+			},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "prod", app.Spec.Source.Path)
+	})
+}
+
+func TestApplyTemplatePatch(t *testing.T) {
+
+	baseApplication := func() *argov1alpha1.Application {
+		return &argov1alpha1.Application{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "test-app",
+				Finalizers: []string{"resources-finalizer.argocd.argoproj.io"},
+			},
+			Spec: argov1alpha1.ApplicationSpec{
+				Source: argov1alpha1.ApplicationSource{
+					RepoURL: "https://git.example.com/repo.git",
+					Path:    "guestbook",
+				},
+				Sources: []argov1alpha1.ApplicationSource{
+					{RepoURL: "https://git.example.com/repo.git", Path: "guestbook"},
+					{RepoURL: "https://git.example.com/other-repo.git", Path: "other-app"},
+				},
+				Destination: argov1alpha1.ApplicationDestination{
+					Server:    "https://kubernetes.default.svc",
+					Namespace: "default",
+				},
+				Project: "default",
+			},
+		}
+	}
+
+	for _, c := range []struct {
+		testName      string
+		patchTemplate string
+		params        map[string]interface{}
+		verify        func(t *testing.T, app *argov1alpha1.Application, err error)
+	}{
+		{
+			testName:      "empty patch is a no-op",
+			patchTemplate: "",
+			params:        map[string]interface{}{},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "guestbook", app.Spec.Source.Path)
+			},
+		},
+		{
+			testName: "json merge patch overwrites a scalar field",
+			patchTemplate: `{
+				"spec": { "project": "{{project}}" }
+			}`,
+			params: map[string]interface{}{"project": "my-project"},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "my-project", app.Spec.Project)
+				// Fields not mentioned in the patch are left untouched.
+				assert.Equal(t, "guestbook", app.Spec.Source.Path)
+			},
+		},
+		{
+			testName: "strategic merge patch sets a nested syncPolicy field",
+			patchTemplate: `
+spec:
+  syncPolicy:
+    automated:
+      prune: {{prune}}
+`,
+			params: map[string]interface{}{"prune": "true"},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.NoError(t, err)
+				assert.NotNil(t, app.Spec.SyncPolicy)
+				assert.NotNil(t, app.Spec.SyncPolicy.Automated)
+				assert.True(t, app.Spec.SyncPolicy.Automated.Prune)
+			},
+		},
+		{
+			testName: "empty top-level field from a conditional template is dropped, not applied",
+			patchTemplate: `
+spec:
+  syncPolicy: {{syncPolicy}}
+`,
+			params: map[string]interface{}{"syncPolicy": "{}"},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.NoError(t, err)
+				assert.Nil(t, app.Spec.SyncPolicy)
+			},
+		},
+		{
+			testName:      "invalid patch YAML returns an error",
+			patchTemplate: "spec: [this is not: valid",
+			params:        map[string]interface{}{},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.Error(t, err)
+			},
+		},
+		{
+			testName: "finalizers set by RenderTemplateParams are preserved through the patch",
+			patchTemplate: `
+spec:
+  project: {{project}}
+`,
+			params: map[string]interface{}{"project": "my-project"},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.NoError(t, err)
+				assert.ElementsMatch(t, []string{"resources-finalizer.argocd.argoproj.io"}, app.Finalizers)
+			},
+		},
+		{
+			// ApplicationSpec.Sources has no patchStrategy/patchMergeKey tag (unlike ObjectMeta's
+			// Finalizers, which inherits "patchStrategy:merge" from client-go), so a strategic merge
+			// patch replaces the whole list rather than merging entry-by-entry. This pins that
+			// behavior down explicitly, so it doesn't regress into looking like a bug.
+			testName: "strategic merge patch replaces Sources wholesale, it does not merge by entry",
+			patchTemplate: `
+spec:
+  sources:
+  - repoURL: {{repoURL}}
+    path: guestbook
+`,
+			params: map[string]interface{}{"repoURL": "https://git.example.com/repo.git"},
+			verify: func(t *testing.T, app *argov1alpha1.Application, err error) {
+				assert.NoError(t, err)
+				// baseApplication starts with two Sources entries; the patch only re-specifies the
+				// first one. If Sources merged by key, both entries would survive; since Sources has
+				// no patchMergeKey, the whole list is replaced by the patch instead.
+				assert.Len(t, app.Spec.Sources, 1)
+				assert.Equal(t, "https://git.example.com/repo.git", app.Spec.Sources[0].RepoURL)
+				assert.Equal(t, "guestbook", app.Spec.Sources[0].Path)
+			},
+		},
+	} {
+		t.Run(c.testName, func(t *testing.T) {
+			render := Render{}
+			app, err := render.ApplyTemplatePatch(baseApplication(), c.patchTemplate, c.params)
+			c.verify(t, app, err)
+		})
+	}
+}
+
+func TestPartitionGeneratorResults(t *testing.T) {
+
+	t.Run("a failing generator doesn't drop params from the healthy ones", func(t *testing.T) {
+		results := []GeneratorResult{
+			{Index: 0, Params: []map[string]interface{}{{"name": "a"}}},
+			{Index: 1, Err: fmt.Errorf("generator is empty")},
+			{Index: 2, Params: []map[string]interface{}{{"name": "c"}}},
+		}
+
+		good, errs := PartitionGeneratorResults(results)
+
+		assert.Equal(t, []map[string]interface{}{{"name": "a"}, {"name": "c"}}, good)
+		assert.Len(t, errs, 1)
+		assert.Equal(t, 1, errs[0].Index)
+		assert.Equal(t, "generator is empty", errs[0].Message)
+	})
+
+	t.Run("every generator failing still treats the set as invalid", func(t *testing.T) {
+		appSet := argoprojiov1alpha1.ApplicationSet{
+			ObjectMeta: metav1.ObjectMeta{Name: "name", Namespace: "namespace"},
+			Spec: argoprojiov1alpha1.ApplicationSetSpec{
+				Generators: []argoprojiov1alpha1.ApplicationSetGenerator{{}, {}},
+			},
+		}
+		hasInvalid, _ := invalidGenerators(&appSet)
+		assert.True(t, hasInvalid)
+
+		results := []GeneratorResult{
+			{Index: 0, Err: fmt.Errorf("generator is empty")},
+			{Index: 1, Err: fmt.Errorf("generator is empty")},
+		}
+		good, errs := PartitionGeneratorResults(results)
+		assert.Empty(t, good)
+		assert.Len(t, errs, 2)
+	})
+}
+
 func TestCheckInvalidGenerators(t *testing.T) {
 
 	scheme := runtime.NewScheme()
@@ -431,6 +669,24 @@ func TestInvalidGenerators(t *testing.T) {
 			expectedInvalid: false,
 			expectedNames:   map[string]bool{},
 		},
+		{
+			testName: "plugin generator is recognized",
+			appSet: argoprojiov1alpha1.ApplicationSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "name",
+					Namespace: "namespace",
+				},
+				Spec: argoprojiov1alpha1.ApplicationSetSpec{
+					Generators: []argoprojiov1alpha1.ApplicationSetGenerator{
+						{
+							Plugin: &argoprojiov1alpha1.PluginGenerator{Name: "my-plugin"},
+						},
+					},
+				},
+			},
+			expectedInvalid: false,
+			expectedNames:   map[string]bool{},
+		},
 		{
 			testName: "invalid generators, no annotation",
 			appSet: argoprojiov1alpha1.ApplicationSet{